@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,7 +17,11 @@ const (
 	dftTOML = ".air.toml"
 	dftYAML = ".air.yaml"
 	dftConf = ".air.conf"
+	dftJSON = ".air.json"
 	airWd   = "air_wd"
+
+	envConfigJSON = "AIR_CONFIG_JSON"
+	envConfigYAML = "AIR_CONFIG_YAML"
 )
 
 type config struct {
@@ -26,6 +31,13 @@ type config struct {
 	Color  cfgColor `toml:"color" mapstructure:"color"`
 	Log    cfgLog   `toml:"log" mapstructure:"log"`
 	Misc   cfgMisc  `toml:"misc" mapstructure:"misc"`
+	Hooks  cfgHooks `toml:"hooks" mapstructure:"hooks"`
+
+	// configPath is the `-c` flag value, if any, that `initConfig` was
+	// called with. It is unexported so viper/mapstructure never populate it
+	// from a config file; it only exists as a fallback anchor for resolving
+	// the working directory if it goes missing later (see resolveWd).
+	configPath string
 }
 
 type cfgBuild struct {
@@ -41,10 +53,24 @@ type cfgBuild struct {
 	StopOnError   bool          `toml:"stop_on_error" mapstructure:"stop_on_error"`
 	SendInterrupt bool          `toml:"send_interrupt" mapstructure:"send_interrupt"`
 	KillDelay     time.Duration `toml:"kill_delay" mapstructure:"kill_delay"`
+	Targets       []cfgTarget   `toml:"targets" mapstructure:"targets"`
+}
+
+// cfgTarget describes one entry of a `[[build.targets]]` matrix, letting a
+// single air config build and run more than one GOOS/GOARCH combination
+// (e.g. a Linux daemon and a Windows service from the same repo).
+type cfgTarget struct {
+	Name   string            `toml:"name" mapstructure:"name"`
+	GOOS   string            `toml:"goos" mapstructure:"goos"`
+	GOARCH string            `toml:"goarch" mapstructure:"goarch"`
+	Cmd    string            `toml:"cmd" mapstructure:"cmd"`
+	Bin    string            `toml:"bin" mapstructure:"bin"`
+	Env    map[string]string `toml:"env" mapstructure:"env"`
 }
 
 type cfgLog struct {
-	AddTime bool `toml:"time" mapstructure:"time"`
+	AddTime bool   `toml:"time" mapstructure:"time"`
+	Debug   string `toml:"debug" mapstructure:"debug"`
 }
 
 type cfgColor struct {
@@ -59,18 +85,36 @@ type cfgMisc struct {
 	CleanOnExit bool `toml:"clean_on_exit" mapstructure:"clean_on_exit"`
 }
 
+// cfgHooks configures notification hooks fired at build/run lifecycle
+// points. Each entry is either a shell command or an HTTP(S) webhook URL,
+// picked by scheme (see runHook).
+type cfgHooks struct {
+	PreBuild  string `toml:"pre_build" mapstructure:"pre_build"`
+	PostBuild string `toml:"post_build" mapstructure:"post_build"`
+	PreRun    string `toml:"pre_run" mapstructure:"pre_run"`
+	PostRun   string `toml:"post_run" mapstructure:"post_run"`
+	OnError   string `toml:"on_error" mapstructure:"on_error"`
+}
+
 func initConfig(path string) (cfg *config, err error) {
 	if path == "" {
-		cfg, err = defaultPathConfig()
+		cfg, err = readConfigFromEnv()
 		if err != nil {
 			return nil, err
 		}
+		if cfg == nil {
+			cfg, err = defaultPathConfig()
+			if err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		cfg, err = readConfigOrDefault(path)
 		if err != nil {
 			return nil, err
 		}
 	}
+	cfg.configPath = path
 	err = mergo.Merge(cfg, defaultConfig())
 	if err != nil {
 		return nil, err
@@ -79,11 +123,39 @@ func initConfig(path string) (cfg *config, err error) {
 	return cfg, err
 }
 
+// readConfigFromEnv decodes a config directly from AIR_CONFIG_JSON or
+// AIR_CONFIG_YAML, with no file on disk, for container/CI contexts where
+// mounting a config file is awkward. It returns a nil config (and nil error)
+// when neither env var is set, so the caller falls back to file probing.
+func readConfigFromEnv() (*config, error) {
+	configType, content := "json", os.Getenv(envConfigJSON)
+	if content == "" {
+		configType, content = "yaml", os.Getenv(envConfigYAML)
+	}
+	if content == "" {
+		return nil, nil
+	}
+	newDebugLogger(cfgLog{}).Debug(tagConfig, "using env-only %s config", configType)
+
+	cfg := new(config)
+	v := viper.New()
+	v.SetConfigType(configType)
+	if err := v.ReadConfig(bytes.NewBufferString(content)); err != nil {
+		return nil, fmt.Errorf("error read config / %w", err)
+	}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error parse config / %w", err)
+	}
+	return cfg, nil
+}
+
 func defaultPathConfig() (*config, error) {
 	// when path is blank, first find `.air.toml`, `.air.conf` in `air_wd` and current working directory, if not found, use defaults
-	for _, name := range []string{dftYAML, dftTOML, dftConf} {
+	dbg := newDebugLogger(cfgLog{})
+	for _, name := range []string{dftYAML, dftTOML, dftConf, dftJSON} {
 		cfg, err := readConfByName(name)
 		if err == nil {
+			dbg.Debug(tagConfig, "found config at %s", name)
 			if name == dftConf {
 				fmt.Println("`.air.conf` will be deprecated soon, recommend using `.air.toml`.")
 			}
@@ -91,25 +163,39 @@ func defaultPathConfig() (*config, error) {
 		}
 	}
 
+	dbg.Debug(tagConfig, "no config file found, using defaults")
 	dftCfg := defaultConfig()
 	return &dftCfg, nil
 }
 
 func readConfByName(name string) (*config, error) {
-	var path string
-	if wd := os.Getenv(airWd); wd != "" {
-		path = filepath.Join(wd, name)
-	} else {
-		wd, err := os.Getwd()
-		if err != nil {
-			return nil, err
-		}
-		path = filepath.Join(wd, name)
+	wd, err := resolveWd("")
+	if err != nil {
+		return nil, err
 	}
-	cfg, err := readConfig(path)
+	cfg, err := readConfig(filepath.Join(wd, name))
 	return cfg, err
 }
 
+// resolveWd returns AIR_WD if set, else os.Getwd(); if os.Getwd() fails
+// (e.g. the working directory was removed mid-run) it falls back to the `-c`
+// config dir, then home.
+func resolveWd(configPath string) (string, error) {
+	if wd := os.Getenv(airWd); wd != "" {
+		return wd, nil
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd, nil
+	}
+	if configPath != "" {
+		return filepath.Dir(configPath), nil
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return home, nil
+	}
+	return "", fmt.Errorf("working directory is gone and no fallback (AIR_WD, -c config dir, or home dir) could be resolved")
+}
+
 func defaultConfig() config {
 	build := cfgBuild{
 		Cmd:         "go build -o ./tmp/main .",
@@ -179,19 +265,43 @@ func readConfigOrDefault(path string) (*config, error) {
 
 func (c *config) preprocess() error {
 	var err error
+	dbg := newDebugLogger(c.Log)
 	cwd := os.Getenv(airWd)
 	if cwd != "" {
 		if err = os.Chdir(cwd); err != nil {
 			return err
 		}
 		c.Root = cwd
+	} else if _, getErr := os.Getwd(); getErr != nil {
+		// cwd is gone; fall back the same way resolveWd does.
+		wd, rerr := resolveWd(c.configPath)
+		if rerr != nil {
+			return fmt.Errorf("working directory is gone: %w", rerr)
+		}
+		dbg.Debug(tagConfig, "working directory gone, falling back to %s", wd)
+		if err = os.Chdir(wd); err != nil {
+			return fmt.Errorf("cannot switch to fallback working directory %q: %w", wd, err)
+		}
+	}
+	if err = interpolate(c); err != nil {
+		return err
+	}
+	dbg.Debug(tagConfig, "build cmd=%q bin=%q after interpolation", c.Build.Cmd, c.Build.Bin)
+	if selector := os.Getenv(airTargetEnv); selector != "" {
+		selected, terr := selectTargets(c, selector)
+		if terr != nil {
+			return terr
+		}
+		dbg.Debug(tagBuild, "AIR_TARGET=%s selected target %s", selector, selected[0].targetName())
+		c.Build.Targets = selected
 	}
+	root := c.Root
 	c.Root, err = expandPath(c.Root)
 	if c.TmpDir == "" {
 		c.TmpDir = "tmp"
 	}
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot resolve root directory %q, the working directory may have been removed: %w", root, err)
 	}
 	ed := c.Build.ExcludeDir
 	for i := range ed {
@@ -207,8 +317,12 @@ func (c *config) preprocess() error {
 	}
 	// Fix windows CMD processor
 	// CMD will not recognize relative path like ./tmp/server
+	bin := c.Build.Bin
 	c.Build.Bin, err = filepath.Abs(c.Build.Bin)
-	return err
+	if err != nil {
+		return fmt.Errorf("cannot resolve absolute path for bin %q, the working directory may have been removed: %w", bin, err)
+	}
+	return nil
 }
 
 func (c *config) colorInfo() map[string]string {
@@ -236,6 +350,46 @@ func (c *config) tmpPath() string {
 	return filepath.Join(c.Root, c.TmpDir)
 }
 
+// targets returns the configured build matrix, falling back to a single
+// target derived from the top-level `build.cmd`/`build.bin` so that configs
+// without `[[build.targets]]` keep today's single-binary behavior.
+func (c *config) targets() []cfgTarget {
+	if len(c.Build.Targets) > 0 {
+		return c.Build.Targets
+	}
+	return []cfgTarget{{
+		Name: "default",
+		Cmd:  c.Build.Cmd,
+		Bin:  c.Build.Bin,
+	}}
+}
+
+// hookSpec returns the configured command/webhook for a lifecycle event, or
+// "" if none is set.
+func (c *config) hookSpec(event string) string {
+	switch event {
+	case eventPreBuild:
+		return c.Hooks.PreBuild
+	case eventPostBuild:
+		return c.Hooks.PostBuild
+	case eventPreRun:
+		return c.Hooks.PreRun
+	case eventPostRun:
+		return c.Hooks.PostRun
+	case eventOnError:
+		return c.Hooks.OnError
+	default:
+		return ""
+	}
+}
+
+// fireHook runs the hook configured for `event`, if any. Hook failures are
+// logged but don't abort the build/run unless `build.stop_on_error` is set.
+func (c *config) fireHook(event string, ctx hookContext) error {
+	ctx.Event = event
+	return fireHook(c.hookSpec(event), ctx, c.Build.StopOnError)
+}
+
 func (c *config) rel(path string) string {
 	s, err := filepath.Rel(c.Root, path)
 	if err != nil {
@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReadConfigFromEnvJSON verifies the AIR_CONFIG_JSON round-trip against
+// the same mapstructure tags a file-based config uses.
+func TestReadConfigFromEnvJSON(t *testing.T) {
+	t.Setenv(envConfigJSON, `{
+		"root": ".",
+		"build": {
+			"cmd": "go build -o ./tmp/main .",
+			"bin": "./tmp/main",
+			"include_ext": ["go", "tpl"]
+		}
+	}`)
+
+	cfg, err := readConfigFromEnv()
+	if err != nil {
+		t.Fatalf("readConfigFromEnv: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("expected a config, got nil")
+	}
+	if cfg.Build.Cmd != "go build -o ./tmp/main ." {
+		t.Errorf("unexpected Build.Cmd: %q", cfg.Build.Cmd)
+	}
+	if len(cfg.Build.IncludeExt) != 2 || cfg.Build.IncludeExt[0] != "go" {
+		t.Errorf("unexpected Build.IncludeExt: %v", cfg.Build.IncludeExt)
+	}
+}
+
+// TestReadConfigFromEnvYAML mirrors TestReadConfigFromEnvJSON for AIR_CONFIG_YAML.
+func TestReadConfigFromEnvYAML(t *testing.T) {
+	t.Setenv(envConfigYAML, "root: .\nbuild:\n  cmd: go build -o ./tmp/main .\n  bin: ./tmp/main\n")
+
+	cfg, err := readConfigFromEnv()
+	if err != nil {
+		t.Fatalf("readConfigFromEnv: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("expected a config, got nil")
+	}
+	if cfg.Build.Bin != "./tmp/main" {
+		t.Errorf("unexpected Build.Bin: %q", cfg.Build.Bin)
+	}
+}
+
+func TestReadConfigFromEnvMalformedJSON(t *testing.T) {
+	t.Setenv(envConfigJSON, "{not valid json")
+	if _, err := readConfigFromEnv(); err == nil {
+		t.Error("expected an error for malformed AIR_CONFIG_JSON")
+	}
+}
+
+func TestReadConfigFromEnvUnset(t *testing.T) {
+	cfg, err := readConfigFromEnv()
+	if err != nil {
+		t.Fatalf("readConfigFromEnv: %v", err)
+	}
+	if cfg != nil {
+		t.Error("expected nil config when neither env var is set")
+	}
+}
+
+// TestDotAirJSONFile verifies the .air.json probe path round-trips through
+// the same mapstructure tags as the env-only path.
+func TestDotAirJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"root": ".", "build": {"cmd": "echo hi", "bin": "./tmp/main"}}`
+	if err := os.WriteFile(filepath.Join(dir, dftJSON), []byte(content), 0o644); err != nil {
+		t.Fatalf("write .air.json: %v", err)
+	}
+	t.Setenv(airWd, dir)
+
+	cfg, err := readConfByName(dftJSON)
+	if err != nil {
+		t.Fatalf("readConfByName: %v", err)
+	}
+	if cfg.Build.Cmd != "echo hi" {
+		t.Errorf("unexpected Build.Cmd: %q", cfg.Build.Cmd)
+	}
+}
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestInitConfigWithRemovedWd ensures initConfig falls back to the home
+// directory (no AIR_WD, no -c path) instead of failing outright when the
+// process's current directory has been removed out from under it (e.g. a
+// rebuild replaced the workspace).
+func TestInitConfigWithRemovedWd(t *testing.T) {
+	base := t.TempDir()
+	removed := filepath.Join(base, "gone")
+	if err := os.Mkdir(removed, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	origWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(removed); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(origWd) }()
+
+	if err := os.RemoveAll(removed); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory available to fall back to")
+	}
+
+	if _, err := initConfig(""); err != nil {
+		t.Fatalf("initConfig with removed wd: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd after initConfig: %v", err)
+	}
+	if wd != home {
+		t.Errorf("expected fallback to home dir %q, got %q", home, wd)
+	}
+}
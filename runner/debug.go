@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// Component tags identifying a debug log site.
+const (
+	tagWatcher = "watcher"
+	tagBuild   = "build"
+	tagRunner  = "runner"
+	tagMain    = "main"
+	tagConfig  = "config"
+)
+
+const envDebug = "AIR_DEBUG"
+
+// debugLogger is an opt-in, tag-filtered verbose channel, silent unless its
+// tag matches one of the configured glob patterns.
+type debugLogger struct {
+	patterns []string
+}
+
+// newDebugLogger reads patterns from AIR_DEBUG (preferred) or cfgLog.Debug.
+func newDebugLogger(cfg cfgLog) *debugLogger {
+	raw := os.Getenv(envDebug)
+	if raw == "" {
+		raw = cfg.Debug
+	}
+	if raw == "" {
+		return &debugLogger{}
+	}
+
+	var patterns []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return &debugLogger{patterns: patterns}
+}
+
+func (d *debugLogger) enabled(tag string) bool {
+	for _, p := range d.patterns {
+		if tagMatches(p, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Debug prints a log.Printf-style line, only when tag is enabled.
+func (d *debugLogger) Debug(tag, format string, args ...interface{}) {
+	if !d.enabled(tag) {
+		return
+	}
+	log.Print("[" + tag + "] " + fmt.Sprintf(format, args...))
+}
+
+// tagMatches reports whether a dot-separated tag matches a dot-separated
+// glob pattern ("*" matches exactly one segment). Every tag above is
+// single-segment today, so "watcher.*" only matches once a site emits a
+// dotted subcomponent tag.
+func tagMatches(pattern, tag string) bool {
+	p := strings.ReplaceAll(pattern, ".", "/")
+	t := strings.ReplaceAll(tag, ".", "/")
+	ok, err := path.Match(p, t)
+	return err == nil && ok
+}
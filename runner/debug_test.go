@@ -0,0 +1,48 @@
+package runner
+
+import "testing"
+
+func TestTagMatches(t *testing.T) {
+	cases := []struct {
+		pattern, tag string
+		want         bool
+	}{
+		{"build", "build", true},
+		{"build", "watcher", false},
+		{"*", "build", true},
+		{"*", "watcher", true},
+		{"watcher.*", "watcher.poll", true},
+		{"watcher.*", "watcher", false},
+		{"watcher.*", "watcher.poll.tick", false},
+		{"a.*", "b.poll", false},
+	}
+	for _, c := range cases {
+		if got := tagMatches(c.pattern, c.tag); got != c.want {
+			t.Errorf("tagMatches(%q, %q) = %v, want %v", c.pattern, c.tag, got, c.want)
+		}
+	}
+}
+
+func TestDebugLoggerEnabled(t *testing.T) {
+	d := newDebugLogger(cfgLog{Debug: "build, watcher.*"})
+	if !d.enabled("build") {
+		t.Error("expected build to be enabled")
+	}
+	if d.enabled("config") {
+		t.Error("expected config to stay disabled")
+	}
+	if !d.enabled("watcher.poll") {
+		t.Error("expected watcher.poll to match watcher.*")
+	}
+}
+
+func TestNewDebugLoggerEnvOverridesConfig(t *testing.T) {
+	t.Setenv(envDebug, "config")
+	d := newDebugLogger(cfgLog{Debug: "build"})
+	if !d.enabled("config") {
+		t.Error("expected AIR_DEBUG env to take precedence over cfgLog.Debug")
+	}
+	if d.enabled("build") {
+		t.Error("expected cfgLog.Debug to be ignored when AIR_DEBUG is set")
+	}
+}
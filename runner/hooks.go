@@ -0,0 +1,109 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// var, not const, so tests can shrink it.
+var hookTimeout = 30 * time.Second
+
+const (
+	eventPreBuild  = "pre_build"
+	eventPostBuild = "post_build"
+	eventPreRun    = "pre_run"
+	eventPostRun   = "post_run"
+	eventOnError   = "on_error"
+)
+
+const (
+	envHookEvent        = "AIR_EVENT"
+	envHookChangedFiles = "AIR_CHANGED_FILES"
+	envHookExitCode     = "AIR_EXIT_CODE"
+	envHookBuildLogPath = "AIR_BUILD_LOG_PATH"
+)
+
+type hookContext struct {
+	Event        string   `json:"event"`
+	ChangedFiles []string `json:"changed_files"`
+	ExitCode     int      `json:"exit_code"`
+	BuildLogPath string   `json:"build_log_path"`
+}
+
+func (ctx hookContext) env() []string {
+	return []string{
+		envHookEvent + "=" + ctx.Event,
+		envHookChangedFiles + "=" + strings.Join(ctx.ChangedFiles, ","),
+		envHookExitCode + "=" + strconv.Itoa(ctx.ExitCode),
+		envHookBuildLogPath + "=" + ctx.BuildLogPath,
+	}
+}
+
+// runHook dispatches a `[hooks]` entry by scheme: http(s) is a webhook,
+// anything else a shell command.
+func runHook(spec string, ctx hookContext) error {
+	if spec == "" {
+		return nil
+	}
+	if u, err := url.Parse(spec); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return runWebhookHook(spec, ctx)
+	}
+	return runShellHook(spec, ctx)
+}
+
+func runShellHook(cmdline string, ctx hookContext) error {
+	runCtx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, "sh", "-c", cmdline)
+	cmd.Env = append(os.Environ(), ctx.env()...)
+	// Killing sh doesn't always kill what it forked; force the pipes closed.
+	cmd.WaitDelay = 500 * time.Millisecond
+	out, err := cmd.CombinedOutput()
+	if runCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("hook %q: timed out after %s", cmdline, hookTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("hook %q: %w: %s", cmdline, err, out)
+	}
+	return nil
+}
+
+func runWebhookHook(webhookURL string, ctx hookContext) error {
+	body, err := json.Marshal(ctx)
+	if err != nil {
+		return fmt.Errorf("hook webhook %q: encode payload: %w", webhookURL, err)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("hook webhook %q: %w", webhookURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("hook webhook %q: status %d", webhookURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// fireHook logs a hook failure and only returns it when stopOnError is set,
+// matching `build.stop_on_error` semantics.
+func fireHook(spec string, ctx hookContext, stopOnError bool) error {
+	if err := runHook(spec, ctx); err != nil {
+		log.Printf("[hook:%s] %v", ctx.Event, err)
+		if stopOnError {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package runner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunShellHookTimesOut(t *testing.T) {
+	orig := hookTimeout
+	hookTimeout = 50 * time.Millisecond
+	defer func() { hookTimeout = orig }()
+
+	err := runShellHook("sleep 5", hookContext{})
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
+
+func TestRunShellHookReceivesContext(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+	ctx := hookContext{Event: eventPostBuild, ChangedFiles: []string{"main.go"}, ExitCode: 0, BuildLogPath: "/tmp/build.log"}
+
+	cmd := "echo \"$" + envHookEvent + " $" + envHookChangedFiles + " $" + envHookExitCode + " $" + envHookBuildLogPath + "\" > " + out
+	if err := runShellHook(cmd, ctx); err != nil {
+		t.Fatalf("runShellHook: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+	want := "post_build main.go 0 /tmp/build.log\n"
+	if string(got) != want {
+		t.Errorf("hook env mismatch: got %q, want %q", got, want)
+	}
+}
+
+func TestRunShellHookNonZeroExit(t *testing.T) {
+	if err := runShellHook("exit 1", hookContext{}); err == nil {
+		t.Error("expected error for non-zero exit")
+	}
+}
+
+func TestFireHookStopOnError(t *testing.T) {
+	if err := fireHook("exit 1", hookContext{}, false); err != nil {
+		t.Errorf("expected no error when stopOnError is false, got %v", err)
+	}
+	if err := fireHook("exit 1", hookContext{}, true); err == nil {
+		t.Error("expected error when stopOnError is true")
+	}
+}
+
+func TestRunHookEmptySpecIsNoop(t *testing.T) {
+	if err := runHook("", hookContext{}); err != nil {
+		t.Errorf("empty spec should be a no-op, got %v", err)
+	}
+}
+
+func TestRunHookSelectsWebhookByScheme(t *testing.T) {
+	var got hookContext
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode webhook payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := hookContext{Event: eventPostBuild, ChangedFiles: []string{"main.go"}, ExitCode: 0, BuildLogPath: "/tmp/build.log"}
+	if err := runHook(srv.URL, ctx); err != nil {
+		t.Fatalf("runHook: %v", err)
+	}
+	if !reflect.DeepEqual(got, ctx) {
+		t.Errorf("webhook payload mismatch: got %+v, want %+v", got, ctx)
+	}
+}
+
+func TestRunHookWebhookErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := runHook(srv.URL, hookContext{}); err == nil {
+		t.Error("expected error for non-2xx webhook response")
+	}
+}
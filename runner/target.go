@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// airTargetEnv picks a single target out of `build.targets`, e.g.
+// `AIR_TARGET=windows air`. There's no `--target` CLI flag yet: this
+// package has no flag-parsing code of its own, only the env var.
+const airTargetEnv = "AIR_TARGET"
+
+// targetName identifies a target: the explicit Name if set, else
+// "<goos>_<goarch>".
+func (t cfgTarget) targetName() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.GOOS + "_" + t.GOARCH
+}
+
+// selectTargets narrows `c.targets()` down to the one named by selector
+// (AIR_TARGET); with no selector it returns all of them.
+func selectTargets(c *config, selector string) ([]cfgTarget, error) {
+	all := c.targets()
+	if selector == "" {
+		return all, nil
+	}
+	for _, t := range all {
+		if t.targetName() == selector {
+			return []cfgTarget{t}, nil
+		}
+	}
+	return nil, fmt.Errorf("no build target named %q in config", selector)
+}
+
+// toolchainDir returns GOROOT/pkg/tool/<goos>_<goarch>.
+func toolchainDir(goos, goarch string) (string, error) {
+	goroot := os.Getenv("GOROOT")
+	if goroot == "" {
+		return "", fmt.Errorf("GOROOT is not set, cannot resolve toolchain for %s/%s", goos, goarch)
+	}
+	return filepath.Join(goroot, "pkg", "tool", goos+"_"+goarch), nil
+}
+
+// targetEnv builds a target's build/run environment: the current process
+// environment, GOOS/GOARCH, its toolchain dir prepended to PATH if one
+// resolves, then the target's own `env` entries.
+func targetEnv(t cfgTarget) []string {
+	env := os.Environ()
+	if t.GOOS != "" {
+		env = append(env, "GOOS="+t.GOOS)
+	}
+	if t.GOARCH != "" {
+		env = append(env, "GOARCH="+t.GOARCH)
+	}
+	if t.GOOS != "" && t.GOARCH != "" {
+		if dir, err := toolchainDir(t.GOOS, t.GOARCH); err == nil {
+			if _, statErr := os.Stat(dir); statErr == nil {
+				env = append(env, "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+			}
+		}
+	}
+	for k, v := range t.Env {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+func buildTarget(t cfgTarget, dbg *debugLogger) error {
+	dbg.Debug(tagBuild, "building target %s: %s", t.targetName(), t.Cmd)
+	cmd := exec.Command("sh", "-c", t.Cmd)
+	cmd.Env = targetEnv(t)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("build target %q: %w: %s", t.targetName(), err, out)
+	}
+	return nil
+}
+
+// buildTargets resolves the selected build matrix (AIR_TARGET narrows it to
+// one) and builds every target in parallel, firing the pre_build/post_build/
+// on_error hooks around the whole matrix.
+func buildTargets(c *config, changedFiles []string) error {
+	targets, err := selectTargets(c, os.Getenv(airTargetEnv))
+	if err != nil {
+		return err
+	}
+
+	ctx := hookContext{ChangedFiles: changedFiles, BuildLogPath: c.buildLogPath()}
+	if err := c.fireHook(eventPreBuild, ctx); err != nil {
+		return err
+	}
+
+	dbg := newDebugLogger(c.Log)
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t cfgTarget) {
+			defer wg.Done()
+			errs[i] = buildTarget(t, dbg)
+		}(i, t)
+	}
+	wg.Wait()
+
+	var buildErr error
+	for _, e := range errs {
+		if e != nil {
+			buildErr = e
+			break
+		}
+	}
+
+	if buildErr != nil {
+		ctx.ExitCode = 1
+		_ = c.fireHook(eventOnError, ctx)
+		return buildErr
+	}
+	return c.fireHook(eventPostBuild, ctx)
+}
+
+// runBuiltTarget runs a single built target's binary to completion, firing
+// pre_run/post_run (or on_error on a non-zero exit) around it.
+func runBuiltTarget(c *config, t cfgTarget, changedFiles []string) error {
+	ctx := hookContext{ChangedFiles: changedFiles, BuildLogPath: c.buildLogPath()}
+	if err := c.fireHook(eventPreRun, ctx); err != nil {
+		return err
+	}
+
+	bin, err := filepath.Abs(t.Bin)
+	if err != nil {
+		return fmt.Errorf("cannot resolve absolute path for target %q bin %q: %w", t.targetName(), t.Bin, err)
+	}
+	cmd := exec.Command(bin)
+	cmd.Env = targetEnv(t)
+	runErr := cmd.Run()
+	ctx.ExitCode = cmd.ProcessState.ExitCode()
+	if runErr != nil {
+		_ = c.fireHook(eventOnError, ctx)
+		return runErr
+	}
+	return c.fireHook(eventPostRun, ctx)
+}
@@ -0,0 +1,132 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testTargetConfig(dir string) *config {
+	return &config{
+		Root:   dir,
+		TmpDir: "tmp",
+		Build: cfgBuild{
+			Targets: []cfgTarget{
+				{Name: "a", Cmd: "touch " + filepath.Join(dir, "a.built")},
+				{Name: "b", Cmd: "touch " + filepath.Join(dir, "b.built")},
+			},
+		},
+	}
+}
+
+func TestBuildTargetsBuildsAllTargets(t *testing.T) {
+	dir := t.TempDir()
+	cfg := testTargetConfig(dir)
+
+	if err := buildTargets(cfg, nil); err != nil {
+		t.Fatalf("buildTargets: %v", err)
+	}
+	for _, name := range []string{"a.built", "b.built"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be built: %v", name, err)
+		}
+	}
+}
+
+func TestAirTargetSelectsOneTarget(t *testing.T) {
+	dir := t.TempDir()
+	cfg := testTargetConfig(dir)
+	t.Setenv(airTargetEnv, "b")
+
+	if err := buildTargets(cfg, nil); err != nil {
+		t.Fatalf("buildTargets: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.built")); err == nil {
+		t.Error("target a should not have built when AIR_TARGET=b")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.built")); err != nil {
+		t.Errorf("expected b.built: %v", err)
+	}
+}
+
+func TestSelectTargetsUnknownName(t *testing.T) {
+	cfg := testTargetConfig(t.TempDir())
+	if _, err := selectTargets(cfg, "nope"); err == nil {
+		t.Error("expected error for unknown target name")
+	}
+}
+
+func TestRunBuiltTargetFiresHooks(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "run.sh")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("write fake binary: %v", err)
+	}
+
+	preMarker := filepath.Join(dir, "pre_run.marker")
+	postMarker := filepath.Join(dir, "post_run.marker")
+	cfg := &config{
+		Root: dir,
+		Hooks: cfgHooks{
+			PreRun:  "touch " + preMarker,
+			PostRun: "touch " + postMarker,
+		},
+	}
+
+	if err := runBuiltTarget(cfg, cfgTarget{Name: "a", Bin: bin}, nil); err != nil {
+		t.Fatalf("runBuiltTarget: %v", err)
+	}
+	for _, marker := range []string{preMarker, postMarker} {
+		if _, err := os.Stat(marker); err != nil {
+			t.Errorf("expected %s to exist: %v", marker, err)
+		}
+	}
+}
+
+func TestRunBuiltTargetPassesChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "run.sh")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("write fake binary: %v", err)
+	}
+
+	out := filepath.Join(dir, "changed.txt")
+	cfg := &config{
+		Root: dir,
+		Hooks: cfgHooks{
+			PreRun: "echo \"$" + envHookChangedFiles + "\" > " + out,
+		},
+	}
+
+	if err := runBuiltTarget(cfg, cfgTarget{Name: "a", Bin: bin}, []string{"main.go", "util.go"}); err != nil {
+		t.Fatalf("runBuiltTarget: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+	if want := "main.go,util.go\n"; string(got) != want {
+		t.Errorf("changed files not passed to hook: got %q, want %q", got, want)
+	}
+}
+
+func TestRunBuiltTargetResolvesRelativeBin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("write fake binary: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	cfg := &config{Root: dir}
+	if err := runBuiltTarget(cfg, cfgTarget{Name: "a", Bin: "./run.sh"}, nil); err != nil {
+		t.Fatalf("runBuiltTarget with relative bin: %v", err)
+	}
+}
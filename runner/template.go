@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs is the function set available when interpolating config
+// values: `env`/`envOr` for environment lookups, `os`/`arch` for the
+// platform air itself runs on (runtime.GOOS/GOARCH, not a build target's
+// goos/goarch), and `cwd` for the working directory.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"envOr": func(name, def string) string {
+			if v := os.Getenv(name); v != "" {
+				return v
+			}
+			return def
+		},
+		"os":   func() string { return runtime.GOOS },
+		"arch": func() string { return runtime.GOARCH },
+		"cwd":  os.Getwd,
+	}
+}
+
+// render executes s as a Go text/template with the config template funcs,
+// binding `.` to cfg so expressions like `{{ .TmpDir }}` can reference other
+// config values. Strings with no template action are returned unchanged.
+func render(cfg *config, s string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	tpl, err := template.New("air-config").Funcs(templateFuncs()).Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, cfg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// interpolate runs root, tmp_dir, the build cmd/bin/full_bin/log, the hook
+// entries, each target's cmd/bin and every include/exclude list entry through
+// `render`; colors, log.debug and a target's name/goos/goarch/env are left as
+// written. It runs before `adaptToVariousPlatforms` so platform adaptation
+// still wins.
+func interpolate(cfg *config) error {
+	fields := []*string{
+		&cfg.Root, &cfg.TmpDir,
+		&cfg.Build.Cmd, &cfg.Build.Bin, &cfg.Build.FullBin, &cfg.Build.Log,
+		&cfg.Hooks.PreBuild, &cfg.Hooks.PostBuild,
+		&cfg.Hooks.PreRun, &cfg.Hooks.PostRun, &cfg.Hooks.OnError,
+	}
+	for i := range cfg.Build.Targets {
+		fields = append(fields, &cfg.Build.Targets[i].Cmd, &cfg.Build.Targets[i].Bin)
+	}
+	for _, f := range fields {
+		rendered, err := render(cfg, *f)
+		if err != nil {
+			return fmt.Errorf("error interpolate config / %w", err)
+		}
+		*f = rendered
+	}
+
+	lists := []*[]string{
+		&cfg.Build.IncludeExt, &cfg.Build.IncludeDir,
+		&cfg.Build.ExcludeDir, &cfg.Build.ExcludeFile,
+	}
+	for _, l := range lists {
+		for i, v := range *l {
+			rendered, err := render(cfg, v)
+			if err != nil {
+				return fmt.Errorf("error interpolate config / %w", err)
+			}
+			(*l)[i] = rendered
+		}
+	}
+	return nil
+}
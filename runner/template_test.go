@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestInterpolateCoversTargetsAndHooks(t *testing.T) {
+	cfg := &config{
+		Build: cfgBuild{
+			Targets: []cfgTarget{
+				{Name: "a", GOOS: "linux", Bin: "./tmp/main-{{ os }}"},
+			},
+		},
+		Hooks: cfgHooks{
+			PreBuild: "echo {{ envOr \"STAGE\" \"dev\" }}",
+		},
+	}
+
+	if err := interpolate(cfg); err != nil {
+		t.Fatalf("interpolate: %v", err)
+	}
+	if want := "./tmp/main-" + runtime.GOOS; cfg.Build.Targets[0].Bin != want {
+		t.Errorf("target bin not interpolated: got %q, want %q", cfg.Build.Targets[0].Bin, want)
+	}
+	if want := "echo dev"; cfg.Hooks.PreBuild != want {
+		t.Errorf("hook not interpolated: got %q, want %q", cfg.Hooks.PreBuild, want)
+	}
+}